@@ -0,0 +1,124 @@
+package execution
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CreateOpts holds the parameters needed to create a new container.
+type CreateOpts struct {
+	Bundle string
+	Stdin  string
+	Stdout string
+	Stderr string
+}
+
+// Container represents a created or running container on the host. It
+// tracks the container's init process along with any exec'd processes and
+// the on-disk state directory used to persist that information across
+// containerd restarts.
+type Container struct {
+	mu sync.Mutex
+
+	id          string
+	bundle      string
+	stateDir    StateDir
+	status      Status
+	processes   map[string]Process
+	initProcess string
+}
+
+// NewContainer creates the on-disk state for a new container and returns
+// the in-memory Container used to track it.
+func NewContainer(root, id, bundle string) (*Container, error) {
+	stateDir := StateDir(filepath.Join(root, id))
+	if err := os.MkdirAll(stateDir.Processes(), 0711); err != nil {
+		return nil, err
+	}
+	return &Container{
+		id:        id,
+		bundle:    bundle,
+		stateDir:  stateDir,
+		status:    Created,
+		processes: make(map[string]Process),
+	}, nil
+}
+
+// LoadContainer reconstructs a Container from its existing state directory,
+// for example when containerd starts back up and calls List.
+func LoadContainer(stateDir StateDir, id, bundle string) *Container {
+	return &Container{
+		id:        id,
+		bundle:    bundle,
+		stateDir:  stateDir,
+		processes: make(map[string]Process),
+	}
+}
+
+func (c *Container) ID() string { return c.id }
+
+func (c *Container) Bundle() string { return c.bundle }
+
+func (c *Container) StateDir() StateDir { return c.stateDir }
+
+// Status returns the container's last known status. Executors should use
+// OCIRuntime.Status for the authoritative, up-to-date value and call
+// SetStatus to keep this cached copy in sync.
+func (c *Container) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// SetStatus updates the container's cached status.
+func (c *Container) SetStatus(s Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status = s
+}
+
+// AddProcess registers a process with the container. init marks the
+// process as the container's init process.
+func (c *Container) AddProcess(p Process, init bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.processes[p.ID()] = p
+	if init {
+		c.initProcess = p.ID()
+	}
+}
+
+// RemoveProcess stops tracking the process with the given id.
+func (c *Container) RemoveProcess(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.processes, id)
+}
+
+// GetProcess returns the tracked process with the given id, or nil if no
+// such process exists.
+func (c *Container) GetProcess(id string) Process {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.processes[id]
+}
+
+// Processes returns every process currently tracked for the container,
+// including its init process.
+func (c *Container) Processes() []Process {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Process, 0, len(c.processes))
+	for _, p := range c.processes {
+		out = append(out, p)
+	}
+	return out
+}
+
+// InitProcess returns the container's init process.
+func (c *Container) InitProcess() Process {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.processes[c.initProcess]
+}
@@ -0,0 +1,27 @@
+package execution
+
+import "time"
+
+// EventType identifies the kind of lifecycle event emitted by an executor.
+type EventType string
+
+const (
+	EventCreate EventType = "create"
+	EventPause  EventType = "pause"
+	EventResume EventType = "resume"
+	EventDelete EventType = "delete"
+	EventExit   EventType = "exit"
+	EventOOM    EventType = "oom"
+)
+
+// Event describes a single lifecycle event for a container or one of its
+// processes, as produced by OCIRuntime.Events.
+type Event struct {
+	Type      EventType
+	ID        string // container ID
+	ProcessID string // set for process-level events, empty otherwise
+	Timestamp time.Time
+
+	// ExitStatus is populated for EventExit.
+	ExitStatus uint32
+}
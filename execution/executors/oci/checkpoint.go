@@ -0,0 +1,224 @@
+package oci
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/crosbymichael/go-runc"
+	"github.com/docker/containerd/execution"
+)
+
+// CheckpointOpts holds the CRIU options exposed by `runc checkpoint`.
+type CheckpointOpts struct {
+	// Name identifies this checkpoint under the container's state dir.
+	Name string
+
+	// ParentPath points at a previous checkpoint's image directory so this
+	// one is dumped incrementally against it, enabling iterative
+	// pre-dumping ahead of a live migration.
+	ParentPath string
+
+	LeaveRunning        bool
+	TCPEstablished      bool
+	ExternalUnixSockets bool
+	ShellJob            bool
+	FileLocks           bool
+	PreDump             bool
+
+	// PageServer is the host:port of a `criu page-server` to stream
+	// memory pages to, used for live migration.
+	PageServer string
+}
+
+// RestoreOpts holds the CRIU options exposed by `runc restore`.
+type RestoreOpts struct {
+	Bundle string
+
+	TCPEstablished      bool
+	ExternalUnixSockets bool
+	ShellJob            bool
+	FileLocks           bool
+
+	// PageServer is the host:port of a `criu page-server` to pull memory
+	// pages from, used for live migration.
+	PageServer string
+}
+
+// checkpointEntry is a single record in a container's checkpoint manifest.
+type checkpointEntry struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Running    bool   `json:"running"`
+	ParentPath string `json:"parentPath,omitempty"`
+}
+
+func checkpointPath(c *execution.Container, name string) string {
+	return filepath.Join(c.StateDir().Checkpoints(), name)
+}
+
+func manifestPath(c *execution.Container) string {
+	return filepath.Join(c.StateDir().Checkpoints(), "manifest.json")
+}
+
+func readManifest(c *execution.Container) ([]checkpointEntry, error) {
+	data, err := ioutil.ReadFile(manifestPath(c))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []checkpointEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func writeManifest(c *execution.Container, entries []checkpointEntry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manifestPath(c), data, 0644)
+}
+
+// Checkpoints returns the names of the checkpoints recorded in c's manifest,
+// in the order they were taken. List and Load do not read the manifest, so
+// the containers they return carry no checkpoint information.
+func (r *OCIRuntime) Checkpoints(c *execution.Container) ([]string, error) {
+	entries, err := readManifest(c)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	return names, nil
+}
+
+// Checkpoint dumps the container's current state, including its memory, to
+// disk via runc/CRIU so it can later be recreated with Restore.
+func (r *OCIRuntime) Checkpoint(c *execution.Container, o CheckpointOpts) error {
+	path := checkpointPath(c, o.Name)
+	if err := os.MkdirAll(path, 0711); err != nil {
+		return err
+	}
+	err := r.runc.Checkpoint(c.ID(), &runc.CheckpointOpts{
+		ImagePath:           path,
+		WorkDir:             path,
+		ParentPath:          o.ParentPath,
+		LeaveRunning:        o.LeaveRunning,
+		TcpEstablished:      o.TCPEstablished,
+		ExternalUnixSockets: o.ExternalUnixSockets,
+		ShellJob:            o.ShellJob,
+		FileLocks:           o.FileLocks,
+		PreDump:             o.PreDump,
+		PageServer:          o.PageServer,
+	})
+	if err != nil {
+		os.RemoveAll(path)
+		return err
+	}
+	entries, err := readManifest(c)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, checkpointEntry{
+		Name:       o.Name,
+		Path:       path,
+		Running:    o.LeaveRunning,
+		ParentPath: o.ParentPath,
+	})
+	return writeManifest(c, entries)
+}
+
+// Restore recreates a container from a checkpoint written by Checkpoint.
+// Only the restored init process is registered with the container and the
+// reaper, the same way load registers each process; other process dirs under
+// the container's state dir are not read.
+func (r *OCIRuntime) Restore(id, name string, o RestoreOpts) (*execution.Container, error) {
+	container, err := execution.NewContainer(r.root, id, o.Bundle)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			container.StateDir().Delete()
+		}
+	}()
+
+	path := checkpointPath(container, name)
+	initDir, err := container.StateDir().NewProcess()
+	if err != nil {
+		return nil, err
+	}
+	pidFile := filepath.Join(initDir, "pid")
+
+	err = r.runc.Restore(id, o.Bundle, &runc.RestoreOpts{
+		ImagePath:           path,
+		WorkDir:             path,
+		Detach:              true,
+		PidFile:             pidFile,
+		TcpEstablished:      o.TCPEstablished,
+		ExternalUnixSockets: o.ExternalUnixSockets,
+		ShellJob:            o.ShellJob,
+		FileLocks:           o.FileLocks,
+		PageServer:          o.PageServer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pid, err := runc.ReadPidFile(pidFile)
+	if err != nil {
+		return nil, err
+	}
+	process, err := newProcess(filepath.Base(initDir), id, initDir, pid)
+	if err != nil {
+		return nil, err
+	}
+	container.AddProcess(process, true)
+	r.reaper.track(process)
+	if _, err = r.Status(container); err != nil {
+		return nil, err
+	}
+	go r.watchOOM(id)
+
+	return container, nil
+}
+
+// Migrate performs the source-side half of a live migration of c to a
+// `criu page-server` listening at dst: a single pre-dump streams the bulk of
+// the container's memory to dst while it keeps running, then a final
+// checkpoint, taken right after with the pre-dump as its parent, stops it.
+// The pre-dump is not repeated and no convergence check is made.
+//
+// Migrate does not itself restore c on the destination host — this
+// OCIRuntime only talks to the local runc/CRIU, it has no way to reach the
+// destination's containerd. Once Migrate returns, the caller is
+// responsible for shipping the checkpoint's non-memory state (everything
+// under its state dir besides what CRIU already streamed) to the
+// destination and calling Restore there with a RestoreOpts whose
+// PageServer also points at dst, so CRIU can pull the remaining pages it
+// needs to complete the restore.
+func (r *OCIRuntime) Migrate(c *execution.Container, dst string, o CheckpointOpts) error {
+	preDump := o
+	preDump.Name = o.Name + "-predump"
+	preDump.PreDump = true
+	preDump.LeaveRunning = true
+	preDump.PageServer = dst
+	if err := r.Checkpoint(c, preDump); err != nil {
+		return fmt.Errorf("oci: pre-dump to %s: %v", dst, err)
+	}
+
+	final := o
+	final.PreDump = false
+	final.LeaveRunning = false
+	final.ParentPath = checkpointPath(c, preDump.Name)
+	final.PageServer = dst
+	return r.Checkpoint(c, final)
+}
@@ -0,0 +1,109 @@
+package oci
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/docker/containerd/execution"
+)
+
+// eventBus fans out lifecycle events to every active Events subscriber.
+type eventBus struct {
+	mu   sync.Mutex
+	subs map[chan execution.Event]struct{}
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subs: make(map[chan execution.Event]struct{})}
+}
+
+func (b *eventBus) subscribe() chan execution.Event {
+	ch := make(chan execution.Event, 128)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *eventBus) unsubscribe(ch chan execution.Event) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+	close(ch)
+}
+
+func (b *eventBus) publish(e execution.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+			// A slow subscriber drops events rather than blocking the
+			// reaper or the runc events watchers that publish them.
+		}
+	}
+}
+
+// Events returns a channel of lifecycle events for every container known to
+// this OCIRuntime: process exits from the reaper, container OOMs from the
+// per-container `runc events` watcher, and the state transitions produced
+// by Create, Pause, Resume, and Delete. The channel is closed once ctx is
+// done.
+func (r *OCIRuntime) Events(ctx context.Context) (<-chan execution.Event, error) {
+	ch := r.events.subscribe()
+	go func() {
+		<-ctx.Done()
+		r.events.unsubscribe(ch)
+	}()
+	return ch, nil
+}
+
+// watchOOM streams `runc events --stats=false` for id for as long as the
+// container exists and republishes any OOM notification it reports.
+func (r *OCIRuntime) watchOOM(id string) {
+	cmd := exec.Command("runc", "--root", r.runc.Root, "events", "--stats=false", id)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		return
+	}
+	defer cmd.Wait()
+
+	dec := json.NewDecoder(bufio.NewReader(stdout))
+	for {
+		var raw struct {
+			Type string `json:"type"`
+		}
+		if err := dec.Decode(&raw); err != nil {
+			return
+		}
+		if raw.Type != "oom" {
+			continue
+		}
+		r.events.publish(execution.Event{
+			Type:      execution.EventOOM,
+			ID:        id,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// attachWatchers starts an OOM watcher for every container already known to
+// the runtime, so events keep flowing across an OCIRuntime restart the same
+// way load recovers the process table.
+func (r *OCIRuntime) attachWatchers() {
+	containers, err := r.List()
+	if err != nil {
+		return
+	}
+	for _, c := range containers {
+		go r.watchOOM(c.ID())
+	}
+}
@@ -7,26 +7,35 @@ import (
 	"os"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/crosbymichael/go-runc"
 	"github.com/docker/containerd/execution"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 var ErrRootEmpty = errors.New("oci: runtime root cannot be an empty string")
 
 func New(root string) *OCIRuntime {
-	return &OCIRuntime{
+	events := newEventBus()
+	r := &OCIRuntime{
 		root: root,
-		Runc: &runc.Runc{
+		runc: &runc.Runc{
 			Root: filepath.Join(root, "runc"),
 		},
+		reaper: newReaper(events),
+		events: events,
 	}
+	r.attachWatchers()
+	return r
 }
 
 type OCIRuntime struct {
 	// root holds runtime state information for the containers
-	root string
-	runc *runc.Runc
+	root   string
+	runc   *runc.Runc
+	reaper *reaper
+	events *eventBus
 }
 
 func (r *OCIRuntime) Create(id string, o execution.CreateOpts) (container *execution.Container, err error) {
@@ -38,12 +47,13 @@ func (r *OCIRuntime) Create(id string, o execution.CreateOpts) (container *execu
 			container.StateDir().Delete()
 		}
 	}()
-	var (
-		initDir = container.StateDir().NewProcess()
-		pidFile = filepath.Join(initDir, "pid")
-	)
+	initDir, err := container.StateDir().NewProcess()
+	if err != nil {
+		return nil, err
+	}
+	pidFile := filepath.Join(initDir, "pid")
 	err = r.runc.Create(id, o.Bundle, &runc.CreateOpts{
-		Pidfile: pidfile,
+		Pidfile: pidFile,
 		Stdin:   o.Stdin,
 		Stdout:  o.Stdout,
 		Stderr:  o.Stderr,
@@ -51,17 +61,24 @@ func (r *OCIRuntime) Create(id string, o execution.CreateOpts) (container *execu
 	if err != nil {
 		return nil, err
 	}
-	pid, err := runc.ReadPifFile(pidfile)
+	pid, err := runc.ReadPidFile(pidFile)
 	if err != nil {
 		// TODO: kill the container if we are going to return
 		return nil, err
 	}
-	process, err := newProcess(filepath.Base(initDir), pid)
+	process, err := newProcess(filepath.Base(initDir), id, initDir, pid)
 	if err != nil {
 		return nil, err
 	}
 
 	container.AddProcess(process, true)
+	r.reaper.track(process)
+	go r.watchOOM(id)
+	r.events.publish(execution.Event{
+		Type:      execution.EventCreate,
+		ID:        id,
+		Timestamp: time.Now(),
+	})
 
 	return container, nil
 }
@@ -78,16 +95,19 @@ func (r *OCIRuntime) load(runcC *runc.Container) (*execution.Container, error) {
 		return nil, err
 	}
 	for _, d := range dirs {
-		pid, err := runc.ReadPidFile(filepath.Join(d, "pid"))
+		procDir := filepath.Join(container.StateDir().Processes(), d.Name())
+		pid, err := runc.ReadPidFile(filepath.Join(procDir, "pid"))
 		if err != nil {
 			return nil, err
 		}
-		process, err := newProcess(filepath.Base(d), pid)
+		process, err := newProcess(d.Name(), runcC.ID, procDir, pid)
 		if err != nil {
 			return nil, err
 		}
 		container.AddProcess(process, pid == runcC.Pid)
+		r.reaper.track(process)
 	}
+	container.SetStatus(toStatus(runcC.Status))
 
 	return container, nil
 }
@@ -98,7 +118,7 @@ func (r *OCIRuntime) List() ([]*execution.Container, error) {
 		return nil, err
 	}
 
-	containers := make([]*execution.Container)
+	containers := make([]*execution.Container, 0, len(runcCs))
 	for _, c := range runcCs {
 		container, err := r.load(c)
 		if err != nil {
@@ -120,73 +140,119 @@ func (r *OCIRuntime) Load(id string) (*execution.Container, error) {
 }
 
 func (r *OCIRuntime) Delete(c *execution.Container) error {
-	if err := r.runc.Delete(c.ID); err != nil {
+	if err := r.runc.Delete(c.ID()); err != nil {
 		return err
 	}
-	c.StateDir.Delete()
+	c.StateDir().Delete()
+	r.events.publish(execution.Event{
+		Type:      execution.EventDelete,
+		ID:        c.ID(),
+		Timestamp: time.Now(),
+	})
 	return nil
 }
 
 func (r *OCIRuntime) Pause(c *execution.Container) error {
-	return r.runc.Pause(c.ID)
+	if err := r.runc.Pause(c.ID()); err != nil {
+		return err
+	}
+	r.events.publish(execution.Event{
+		Type:      execution.EventPause,
+		ID:        c.ID(),
+		Timestamp: time.Now(),
+	})
+	return nil
 }
 
 func (r *OCIRuntime) Resume(c *execution.Container) error {
-	return r.runc.Resume(c.ID)
+	if err := r.runc.Resume(c.ID()); err != nil {
+		return err
+	}
+	r.events.publish(execution.Event{
+		Type:      execution.EventResume,
+		ID:        c.ID(),
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+// CreateProcessOpts holds the parameters needed to start a new process
+// inside a running container via `runc exec`.
+type CreateProcessOpts struct {
+	Spec   *specs.Process
+	Stdin  string
+	Stdout string
+	Stderr string
 }
 
 func (r *OCIRuntime) StartProcess(c *execution.Container, o CreateProcessOpts) (execution.Process, error) {
 	var err error
 
-	processStateDir, err := c.StateDir.NewProcess()
+	processStateDir, err := c.StateDir().NewProcess()
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
 		if err != nil {
-			c.StateDir.DeleteProcess(filepath.Base(processStateDir))
+			c.StateDir().DeleteProcess(filepath.Base(processStateDir))
 		}
 	}()
 
-	pidFile := filepath.Join(processStateDir, id)
-	err := r.runc.ExecProcess(c.ID, o.spec, &runc.ExecOpts{
-		PidFile: pidfile,
+	pidFile := filepath.Join(processStateDir, "pid")
+	err = r.runc.ExecProcess(c.ID(), o.Spec, &runc.ExecOpts{
+		PidFile: pidFile,
 		Detach:  true,
-		Stdin:   o.stdin,
-		Stdout:  o.stdout,
-		Stderr:  o.stderr,
+		Stdin:   o.Stdin,
+		Stdout:  o.Stdout,
+		Stderr:  o.Stderr,
 	})
 	if err != nil {
 		return nil, err
 	}
-	pid, err := runc.ReadPidFile(pidfile)
+	pid, err := runc.ReadPidFile(pidFile)
 	if err != nil {
 		return nil, err
 	}
 
-	process, err := newProcess(pid)
+	p, err := newProcess(filepath.Base(processStateDir), c.ID(), processStateDir, pid)
 	if err != nil {
 		return nil, err
 	}
 
-	container.AddProcess(process, false)
+	c.AddProcess(p, false)
+	r.reaper.track(p)
 
-	return process, nil
+	return p, nil
+}
+
+// Wait blocks until the process identified by processID exits and returns
+// its exit status. The status is produced by the reaper's SIGCHLD handler
+// and, once recorded, survives an OCIRuntime restart.
+func (r *OCIRuntime) Wait(c *execution.Container, processID string) (uint32, error) {
+	p := c.GetProcess(processID)
+	if p == nil {
+		return 0, fmt.Errorf("oci: process %s not found", processID)
+	}
+	return p.Wait()
 }
 
 func (r *OCIRuntime) SignalProcess(c *execution.Container, id string, sig os.Signal) error {
-	process := c.GetProcess(id)
-	if process == nil {
-		return fmt.Errorf("Make a Process Not Found error")
+	p := c.GetProcess(id)
+	if p == nil {
+		return fmt.Errorf("oci: process %s not found", id)
+	}
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return fmt.Errorf("oci: unsupported signal %v", sig)
 	}
-	return syscall.Kill(int(process.Pid()), os.Signal)
+	return syscall.Kill(p.Pid(), s)
 }
 
-func (r *OCIRuntime) GetProcess(c *execution.Container, id string) process {
+func (r *OCIRuntime) GetProcess(c *execution.Container, id string) execution.Process {
 	return c.GetProcess(id)
 }
 
 func (r *OCIRuntime) DeleteProcess(c *execution.Container, id string) error {
-	c.StateDir.DeleteProcess(id)
+	c.StateDir().DeleteProcess(id)
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,119 @@
+package oci
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/docker/containerd/execution"
+)
+
+// process is the OCIRuntime's implementation of execution.Process. It wraps
+// the pid runc reports for a container's init or exec'd process and tracks
+// its exit status once the reaper collects it.
+type process struct {
+	mu sync.Mutex
+
+	id          string
+	containerID string
+	dir         string
+	pid         int
+
+	exited     bool
+	exitStatus uint32
+	exitCh     chan struct{}
+}
+
+func newProcess(id, containerID, dir string, pid int) (*process, error) {
+	p := &process{
+		id:          id,
+		containerID: containerID,
+		dir:         dir,
+		pid:         pid,
+		exitCh:      make(chan struct{}),
+	}
+	// Recover the exit status left behind by a previous OCIRuntime, so a
+	// process that exited while containerd was down is not reported as
+	// running forever after a restart.
+	status, exited, err := readExitStatus(dir)
+	if err != nil {
+		return nil, err
+	}
+	if exited {
+		p.exited = true
+		p.exitStatus = status
+		close(p.exitCh)
+	}
+	return p, nil
+}
+
+func (p *process) ID() string {
+	return p.id
+}
+
+func (p *process) Pid() int {
+	return p.pid
+}
+
+func (p *process) Status() execution.Status {
+	if p.hasExited() {
+		return execution.Stopped
+	}
+	if err := checkAlive(p.pid); err != nil {
+		return execution.Stopped
+	}
+	return execution.Running
+}
+
+func (p *process) hasExited() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.exited
+}
+
+// Wait blocks until the process exits and returns its exit status.
+func (p *process) Wait() (uint32, error) {
+	<-p.exitCh
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.exitStatus, nil
+}
+
+// setExited records the process' exit status, persists it under the
+// process' state dir so it survives an OCIRuntime restart, and unblocks any
+// Wait callers.
+func (p *process) setExited(status uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.exited {
+		return
+	}
+	p.exited = true
+	p.exitStatus = status
+	// Best effort: Wait callers in this process are unblocked regardless,
+	// they just won't be able to recover the status after a restart.
+	writeExitStatus(p.dir, status)
+	close(p.exitCh)
+}
+
+func writeExitStatus(dir string, status uint32) error {
+	return ioutil.WriteFile(filepath.Join(dir, "exit"), []byte(strconv.FormatUint(uint64(status), 10)), 0644)
+}
+
+func readExitStatus(dir string) (status uint32, exited bool, err error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "exit"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	s, err := strconv.ParseUint(string(data), 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("oci: invalid exit status in %s: %v", dir, err)
+	}
+	return uint32(s), true, nil
+}
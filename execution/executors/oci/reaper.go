@@ -0,0 +1,107 @@
+package oci
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/docker/containerd/execution"
+)
+
+// reaper reaps exited children via SIGCHLD in a dedicated goroutine and
+// routes their exit status to whichever tracked process owns that pid, be
+// it a container's init process or one started with StartProcess.
+type reaper struct {
+	mu sync.Mutex
+
+	sc    chan os.Signal
+	procs map[int]*process
+	bus   *eventBus
+}
+
+func newReaper(bus *eventBus) *reaper {
+	// runc create/exec --detach fork the container's init or exec'd
+	// process and then the runc CLI itself exits, orphaning it. Without
+	// PR_SET_CHILD_SUBREAPER that orphan reparents to PID 1, not to us,
+	// and Wait4 on its pid would fail with ECHILD forever. Best effort:
+	// if this fails (e.g. unsupported kernel) reaping will only work for
+	// processes that happen to stay direct children.
+	syscall.Prctl(syscall.PR_SET_CHILD_SUBREAPER, 1, 0, 0, 0)
+
+	r := &reaper{
+		sc:    make(chan os.Signal, 32),
+		procs: make(map[int]*process),
+		bus:   bus,
+	}
+	signal.Notify(r.sc, syscall.SIGCHLD)
+	go r.run()
+	return r
+}
+
+func (r *reaper) run() {
+	for range r.sc {
+		r.reap()
+	}
+}
+
+// reap checks every pid we track for an exit without blocking, so a single
+// SIGCHLD that coalesces multiple exits still reaps all of them. It only
+// ever waits on pids registered via track: reaping with pid -1 would also
+// collect children this OCIRuntime doesn't own, such as the runc/CRIU
+// processes go-runc shells out to or the `runc events` watcher in
+// watchOOM, both of which rely on os/exec's own wait4 to reap their child
+// and would otherwise intermittently lose the race for its exit status.
+func (r *reaper) reap() {
+	r.mu.Lock()
+	pids := make([]int, 0, len(r.procs))
+	for pid := range r.procs {
+		pids = append(pids, pid)
+	}
+	r.mu.Unlock()
+
+	for _, pid := range pids {
+		var ws syscall.WaitStatus
+		wpid, err := syscall.Wait4(pid, &ws, syscall.WNOHANG, nil)
+		if err != nil || wpid != pid {
+			continue
+		}
+		r.exit(pid, exitStatus(ws))
+	}
+}
+
+func exitStatus(ws syscall.WaitStatus) uint32 {
+	if ws.Signaled() {
+		return 128 + uint32(ws.Signal())
+	}
+	return uint32(ws.ExitStatus())
+}
+
+// track registers a process so the reaper can match it against the pid
+// reported by a future SIGCHLD.
+func (r *reaper) track(p *process) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.procs[p.pid] = p
+}
+
+func (r *reaper) exit(pid int, status uint32) {
+	r.mu.Lock()
+	p, ok := r.procs[pid]
+	if ok {
+		delete(r.procs, pid)
+	}
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	p.setExited(status)
+	r.bus.publish(execution.Event{
+		Type:       execution.EventExit,
+		ID:         p.containerID,
+		ProcessID:  p.id,
+		Timestamp:  time.Now(),
+		ExitStatus: status,
+	})
+}
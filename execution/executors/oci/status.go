@@ -0,0 +1,60 @@
+package oci
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/docker/containerd/execution"
+)
+
+// toStatus maps a runc status string onto the normalized execution.Status
+// enum. This is the single place that mapping happens so that Status,
+// List, and Load can never disagree about what "stopped" means.
+func toStatus(s string) execution.Status {
+	switch s {
+	case "created":
+		return execution.Created
+	case "running":
+		return execution.Running
+	case "paused":
+		return execution.Paused
+	case "pausing":
+		return execution.Pausing
+	case "stopped", "exited", "destroyed":
+		return execution.Stopped
+	default:
+		return execution.Unknown
+	}
+}
+
+// checkAlive returns nil if the process with the given pid is still alive.
+func checkAlive(pid int) error {
+	return syscall.Kill(pid, 0)
+}
+
+// Status returns the normalized state of the container's init process, as
+// reported by `runc state`.
+func (r *OCIRuntime) Status(c *execution.Container) (execution.Status, error) {
+	state, err := r.runc.State(c.ID())
+	if err != nil {
+		return execution.Unknown, err
+	}
+	status := toStatus(state.Status)
+	c.SetStatus(status)
+	return status, nil
+}
+
+// ProcessStatus returns the normalized state of a single process within c.
+// For the init process this is the same as Status; for exec'd processes,
+// which runc does not track individually, it is derived from whether the
+// pid is still alive.
+func (r *OCIRuntime) ProcessStatus(c *execution.Container, processID string) (execution.Status, error) {
+	p := c.GetProcess(processID)
+	if p == nil {
+		return execution.Unknown, fmt.Errorf("oci: process %s not found", processID)
+	}
+	if p.ID() == c.InitProcess().ID() {
+		return r.Status(c)
+	}
+	return p.Status(), nil
+}
@@ -0,0 +1,33 @@
+package oci
+
+import (
+	"testing"
+
+	"github.com/docker/containerd/execution"
+)
+
+// TestToStatus fakes the state string `runc state`/`runc list` put on the
+// wire for each state runc reports and confirms it maps onto the expected
+// execution.Status, and that the enum round-trips back through its String
+// representation the way Status/List/Load expect callers to see it.
+func TestToStatus(t *testing.T) {
+	for runcState, want := range map[string]execution.Status{
+		"created":   execution.Created,
+		"running":   execution.Running,
+		"paused":    execution.Paused,
+		"pausing":   execution.Pausing,
+		"stopped":   execution.Stopped,
+		"exited":    execution.Stopped,
+		"destroyed": execution.Stopped,
+		"":          execution.Unknown,
+		"bogus":     execution.Unknown,
+	} {
+		got := toStatus(runcState)
+		if got != want {
+			t.Errorf("toStatus(%q) = %v, want %v", runcState, got, want)
+		}
+		if back := toStatus(got.String()); back != got {
+			t.Errorf("toStatus(%q) = %v, but toStatus(%q) = %v", runcState, got, got.String(), back)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package execution
+
+// Process is a single process running inside a container. The container's
+// init process is a Process like any other, distinguished only by the flag
+// passed to Container.AddProcess.
+type Process interface {
+	ID() string
+	Pid() int
+	Status() Status
+
+	// Wait blocks until the process exits and returns its exit status.
+	Wait() (uint32, error)
+}
@@ -0,0 +1,42 @@
+package execution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// StateDir is the on-disk directory containerd uses to persist everything
+// it needs to know about a container so that it can be recovered across
+// containerd restarts.
+type StateDir string
+
+// Processes returns the directory holding per-process state.
+func (s StateDir) Processes() string {
+	return filepath.Join(string(s), "processes")
+}
+
+// Checkpoints returns the directory holding the container's checkpoint
+// images and their manifest.
+func (s StateDir) Checkpoints() string {
+	return filepath.Join(string(s), "checkpoints")
+}
+
+// NewProcess creates a new, uniquely named directory under Processes() to
+// hold the state for a single process and returns its path.
+func (s StateDir) NewProcess() (string, error) {
+	if err := os.MkdirAll(s.Processes(), 0711); err != nil {
+		return "", err
+	}
+	return ioutil.TempDir(s.Processes(), "")
+}
+
+// DeleteProcess removes the on-disk state for a single process.
+func (s StateDir) DeleteProcess(id string) error {
+	return os.RemoveAll(filepath.Join(s.Processes(), id))
+}
+
+// Delete removes all on-disk state for the container.
+func (s StateDir) Delete() error {
+	return os.RemoveAll(string(s))
+}
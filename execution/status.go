@@ -0,0 +1,35 @@
+package execution
+
+// Status is the normalized state of a container or process. Every executor
+// is responsible for mapping its runtime-specific state strings onto one of
+// these values so that callers see the same status regardless of whether it
+// came from Create, List, or Load.
+type Status int
+
+const (
+	// Unknown is returned when the executor cannot map the runtime's
+	// reported state onto one of the known values below.
+	Unknown Status = iota
+	Created
+	Running
+	Paused
+	Pausing
+	Stopped
+)
+
+func (s Status) String() string {
+	switch s {
+	case Created:
+		return "created"
+	case Running:
+		return "running"
+	case Paused:
+		return "paused"
+	case Pausing:
+		return "pausing"
+	case Stopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
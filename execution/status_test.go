@@ -0,0 +1,19 @@
+package execution
+
+import "testing"
+
+func TestStatusString(t *testing.T) {
+	for status, want := range map[Status]string{
+		Created:        "created",
+		Running:        "running",
+		Paused:         "paused",
+		Pausing:        "pausing",
+		Stopped:        "stopped",
+		Unknown:        "unknown",
+		Status(100001): "unknown",
+	} {
+		if got := status.String(); got != want {
+			t.Errorf("Status(%d).String() = %q, want %q", status, got, want)
+		}
+	}
+}